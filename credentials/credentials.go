@@ -0,0 +1,23 @@
+// Package credentials holds the Salesforce OAuth2 username-password flow
+// credentials used by the session package to authenticate.
+package credentials
+
+// Credentials holds the OAuth2 username-password flow credentials used to
+// authenticate with Salesforce.
+type Credentials struct {
+	Username     string
+	Password     string
+	ClientID     string
+	ClientSecret string
+}
+
+// New returns new Credentials for the given username, password, client id
+// and client secret.
+func New(username, password, clientID, clientSecret string) *Credentials {
+	return &Credentials{
+		Username:     username,
+		Password:     password,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+}
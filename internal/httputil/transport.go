@@ -0,0 +1,41 @@
+// Package httputil holds small http.Transport helpers shared by the session
+// and restclient packages' functional options.
+package httputil
+
+import "net/http"
+
+// CloneDefaultTransport returns a copy of http.DefaultTransport so it can be
+// safely mutated (e.g. to set TLSClientConfig or Proxy) without affecting
+// the process-wide default.
+func CloneDefaultTransport() *http.Transport {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return &http.Transport{}
+}
+
+// TransportClient returns *client, allocating a new *http.Client and
+// storing it through the pointer if *client is nil or still
+// http.DefaultClient. session.Session and restclient.Client each embed an
+// *http.Client field and call this (via their own unexported
+// transportClient method) so a TLS/transport/proxy option never mutates
+// http.DefaultClient itself.
+func TransportClient(client **http.Client) *http.Client {
+	if *client == nil || *client == http.DefaultClient {
+		*client = &http.Client{}
+	}
+	return *client
+}
+
+// Transport returns the *http.Transport backing *client (see
+// TransportClient), cloning http.DefaultTransport into it if it isn't one
+// already.
+func Transport(client **http.Client) *http.Transport {
+	c := TransportClient(client)
+	t, ok := c.Transport.(*http.Transport)
+	if !ok || t == nil {
+		t = CloneDefaultTransport()
+		c.Transport = t
+	}
+	return t
+}
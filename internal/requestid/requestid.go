@@ -0,0 +1,35 @@
+// Package requestid provides the context plumbing used to propagate a
+// single request ID across the session and restclient packages without
+// introducing an import cycle between them.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header used to propagate a request ID to Salesforce.
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx that carries id.
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, and whether one was present.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
+
+// FromContextOrNew returns the request ID stored in ctx, generating a new
+// UUIDv4 if ctx doesn't carry one.
+func FromContextOrNew(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok && id != "" {
+		return id
+	}
+	return uuid.New().String()
+}
@@ -0,0 +1,80 @@
+package testserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// CompositeSubResponseScript describes one scripted sub-response returned by
+// CompositeHandler, matched by position to the request's compositeRequest
+// entries.
+type CompositeSubResponseScript struct {
+	StatusCode int
+	Body       interface{}
+}
+
+// CompositeHandler returns an http.HandlerFunc that validates the incoming
+// request against path using the same MethodValidator/PathValidator
+// composition as the SObject handlers, decodes it as a Composite or
+// Composite Batch request with exactly len(responses) sub-requests, then
+// responds with one sub-response per entry in responses. For a Composite
+// request each sub-response carries the matching sub-request's referenceId;
+// for a Composite Batch request the sub-responses are batch results
+// (statusCode and result, no referenceId) alongside hasErrors.
+func CompositeHandler(t *testing.T, msg, path string, responses []CompositeSubResponseScript) http.HandlerFunc {
+	validators := []RequestValidator{
+		&MethodValidator{Method: http.MethodPost},
+		&PathValidator{Path: path},
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, v := range validators {
+			v.Validate(t, msg, r)
+		}
+
+		var req struct {
+			CompositeRequest []struct {
+				ReferenceID string `json:"referenceId"`
+			} `json:"compositeRequest"`
+			BatchRequests []struct {
+				ReferenceID string `json:"referenceId"`
+			} `json:"batchRequests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("%s: failed to decode request body: %v", msg, err)
+		}
+		isBatch := len(req.BatchRequests) > 0
+		subRequests := req.CompositeRequest
+		if isBatch {
+			subRequests = req.BatchRequests
+		}
+		if len(subRequests) != len(responses) {
+			t.Errorf("%s: got %d sub-requests, want %d", msg, len(subRequests), len(responses))
+			return
+		}
+
+		var respBody map[string]interface{}
+		if isBatch {
+			results := make([]map[string]interface{}, len(subRequests))
+			hasErrors := false
+			for i, resp := range responses {
+				results[i] = map[string]interface{}{"statusCode": resp.StatusCode, "result": resp.Body}
+				hasErrors = hasErrors || resp.StatusCode >= 300
+			}
+			respBody = map[string]interface{}{"hasErrors": hasErrors, "results": results}
+		} else {
+			subResponses := make([]map[string]interface{}, len(subRequests))
+			for i, sub := range subRequests {
+				resp := responses[i]
+				subResponses[i] = map[string]interface{}{
+					"body":           resp.Body,
+					"httpStatusCode": resp.StatusCode,
+					"referenceId":    sub.ReferenceID,
+				}
+			}
+			respBody = map[string]interface{}{"compositeResponse": subResponses}
+		}
+
+		(&JSONResponseHandler{StatusCode: http.StatusOK, Body: respBody}).handle(t, msg, w)
+	}
+}
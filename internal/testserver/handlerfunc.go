@@ -0,0 +1,60 @@
+package testserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// StaticJSONHandler returns an http.HandlerFunc that always responds with
+// body JSON-encoded and the given status code. t fails the test if body
+// can't be encoded.
+func StaticJSONHandler(t *testing.T, body interface{}, statusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Errorf("failed to encode response body: %v", err)
+		}
+	}
+}
+
+// ValidateJSONBodyHandler returns an http.HandlerFunc that asserts the
+// request body JSON-decodes to a value equal to wantBody, failing the test
+// with errMsg if it doesn't, then responds with respBody JSON-encoded and
+// the given status code.
+func ValidateJSONBodyHandler(t *testing.T, wantBody, respBody interface{}, statusCode int,
+	errMsg string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		want, err := jsonObjectToMap(wantBody)
+		if err != nil {
+			t.Errorf("failed to convert wanted body to map: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Error(errMsg)
+		}
+
+		w.WriteHeader(statusCode)
+		if err := json.NewEncoder(w).Encode(respBody); err != nil {
+			t.Errorf("failed to encode response body: %v", err)
+		}
+	}
+}
+
+// jsonObjectToMap round-trips object through JSON, returning it as a
+// map[string]interface{} so it can be compared against a decoded request body.
+func jsonObjectToMap(object interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
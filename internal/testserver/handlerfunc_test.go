@@ -11,7 +11,7 @@ import (
 
 func TestStaticJSONHandler(t *testing.T) {
 	// start server
-	s := New()
+	s := New(t)
 	s.Start()
 	defer s.Stop()
 
@@ -20,7 +20,7 @@ func TestStaticJSONHandler(t *testing.T) {
 		"field1": "one",
 		"field2": 2.0,
 	}
-	s.HandlerFunc = StaticJSONHandler(want, http.StatusCreated)
+	s.HandlerFunc = StaticJSONHandler(t, want, http.StatusCreated)
 
 	// get response using http client
 	resp, err := s.Client().Get(s.URL())
@@ -37,7 +37,7 @@ func TestStaticJSONHandler(t *testing.T) {
 
 func TestValidateJSONBodyHandler(t *testing.T) {
 	// start server
-	s := New()
+	s := New(t)
 	s.Start()
 	defer s.Stop()
 
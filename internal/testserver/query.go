@@ -0,0 +1,51 @@
+package testserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// QueryHandler returns an http.HandlerFunc that validates the incoming
+// request against path (e.g. "/services/data/v50.0/query") with a "q"
+// query parameter equal to wantSOQL, using the same
+// MethodValidator/PathValidator/QueryValidator composition as the SObject
+// handlers, then serves records paginated pageSize at a time, following
+// the fake nextRecordsUrl it hands back across subsequent requests.
+func QueryHandler(t *testing.T, msg, path, wantSOQL string, records []interface{}, pageSize int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := 0
+		if r.URL.Path == path {
+			validators := []RequestValidator{
+				&MethodValidator{Method: http.MethodGet},
+				&PathValidator{Path: path},
+				&QueryValidator{Query: url.Values{"q": []string{wantSOQL}}},
+			}
+			for _, v := range validators {
+				v.Validate(t, msg, r)
+			}
+		} else {
+			(&MethodValidator{Method: http.MethodGet}).Validate(t, msg, r)
+			if _, err := fmt.Sscanf(r.URL.Path, path+"/%d", &start); err != nil {
+				t.Errorf("%s: unexpected path %q", msg, r.URL.Path)
+			}
+		}
+
+		end := start + pageSize
+		if end > len(records) {
+			end = len(records)
+		}
+		done := end >= len(records)
+		resp := map[string]interface{}{
+			"totalSize": len(records),
+			"done":      done,
+			"records":   records[start:end],
+		}
+		if !done {
+			resp["nextRecordsUrl"] = fmt.Sprintf("%s/%d", path, end)
+		}
+
+		(&JSONResponseHandler{StatusCode: http.StatusOK, Body: resp}).handle(t, msg, w)
+	}
+}
@@ -0,0 +1,33 @@
+package testserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/internal/requestid"
+)
+
+// RequestIDValidator asserts that the request carries a non-empty
+// X-Request-ID header, and that every request validated against the same
+// RequestIDValidator instance carries the same ID. Share one instance
+// across every step of a scripted scenario (login, retry, ...) to assert
+// that the whole chain was stitched together with a single ID.
+type RequestIDValidator struct {
+	id string
+}
+
+// Validate implements RequestValidator.
+func (v *RequestIDValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	got := r.Header.Get(requestid.Header)
+	if got == "" {
+		t.Errorf("%s: missing %s header", msg, requestid.Header)
+		return
+	}
+	if v.id == "" {
+		v.id = got
+		return
+	}
+	if got != v.id {
+		t.Errorf("%s: %s = %q, want %q (from an earlier request in this scenario)", msg, requestid.Header, got, v.id)
+	}
+}
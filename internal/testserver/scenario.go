@@ -0,0 +1,99 @@
+package testserver
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// Step is one scripted step in a Scenario: when its turn comes, every
+// Validator runs against the incoming request before Handler responds.
+type Step struct {
+	Validators []RequestValidator
+	Handler    http.HandlerFunc
+
+	repeat int
+}
+
+// Repeat returns a copy of s that matches the next n requests instead of
+// just one, so a run of identical steps (e.g. three successful upserts)
+// doesn't need to be spelled out n times.
+func (s Step) Repeat(n int) Step {
+	s.repeat = n
+	return s
+}
+
+func (s Step) count() int {
+	if s.repeat <= 0 {
+		return 1
+	}
+	return s.repeat
+}
+
+// Scenario tracks the cursor through a sequence of Steps served by a
+// ScenarioHandler.
+type Scenario struct {
+	t            *testing.T
+	steps        []Step
+	always       http.HandlerFunc
+	requestCount int
+	stepIndex    int
+	stepRequests int
+}
+
+// Always sets the handler the Scenario falls back to once every Step has
+// run its course, instead of failing the test on the next request.
+func (s *Scenario) Always(handler http.HandlerFunc) *Scenario {
+	s.always = handler
+	return s
+}
+
+// Remaining returns the number of scripted requests, across every Step,
+// that haven't been served yet. Assert it's 0 with defer to catch a
+// scenario that didn't run to completion.
+func (s *Scenario) Remaining() int {
+	remaining := 0
+	for i := s.stepIndex; i < len(s.steps); i++ {
+		count := s.steps[i].count()
+		if i == s.stepIndex {
+			count -= s.stepRequests
+		}
+		remaining += count
+	}
+	return remaining
+}
+
+func (s *Scenario) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requestCount++
+	if s.stepIndex >= len(s.steps) {
+		if s.always != nil {
+			s.always(w, r)
+			return
+		}
+		s.t.Errorf("scenario: request %d exceeds the %d scripted step(s)", s.requestCount, len(s.steps))
+		return
+	}
+
+	step := s.steps[s.stepIndex]
+	msg := fmt.Sprintf("scenario step %d (request %d)", s.stepIndex+1, s.requestCount)
+	for _, v := range step.Validators {
+		v.Validate(s.t, msg, r)
+	}
+	step.Handler(w, r)
+
+	s.stepRequests++
+	if s.stepRequests >= step.count() {
+		s.stepIndex++
+		s.stepRequests = 0
+	}
+}
+
+// ScenarioHandler returns an http.HandlerFunc that serves steps in order,
+// advancing past a Step once it's matched Repeat(n) times (once by
+// default), and the *Scenario driving it. Use Always to set a fallback for
+// requests past the end of the script, and Remaining (typically with
+// defer) to assert the scenario ran to completion.
+func ScenarioHandler(t *testing.T, steps ...Step) (http.HandlerFunc, *Scenario) {
+	s := &Scenario{t: t, steps: steps}
+	return s.serveHTTP, s
+}
@@ -0,0 +1,59 @@
+package testserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenarioHandler(t *testing.T) {
+	// start server
+	s := New(t)
+	s.Start()
+	defer s.Stop()
+
+	methodValidator := &MethodValidator{Method: http.MethodGet}
+	handlerFunc, scenario := ScenarioHandler(t,
+		Step{Validators: []RequestValidator{methodValidator}, Handler: StaticJSONHandler(t, "first", http.StatusOK)},
+		Step{Handler: StaticJSONHandler(t, "repeated", http.StatusOK)}.Repeat(2),
+	)
+	s.HandlerFunc = handlerFunc
+	assert.Equal(t, 3, scenario.Remaining())
+
+	for i, want := range []string{"first", "repeated", "repeated"} {
+		resp, err := s.Client().Get(s.URL())
+		assert.Nil(t, err)
+		var got string
+		assert.Nil(t, decodeJSONBody(resp, &got))
+		assert.Equal(t, want, got, "request %d", i+1)
+	}
+	assert.Equal(t, 0, scenario.Remaining())
+}
+
+func TestScenarioHandler_Always(t *testing.T) {
+	// start server
+	s := New(t)
+	s.Start()
+	defer s.Stop()
+
+	handlerFunc, scenario := ScenarioHandler(t,
+		Step{Handler: StaticJSONHandler(t, "scripted", http.StatusOK)},
+	)
+	scenario.Always(StaticJSONHandler(t, "fallback", http.StatusOK))
+	s.HandlerFunc = handlerFunc
+
+	for _, want := range []string{"scripted", "fallback", "fallback"} {
+		resp, err := s.Client().Get(s.URL())
+		assert.Nil(t, err)
+		var got string
+		assert.Nil(t, decodeJSONBody(resp, &got))
+		assert.Equal(t, want, got)
+	}
+}
+
+func decodeJSONBody(resp *http.Response, v interface{}) error {
+	defer func() { _ = resp.Body.Close() }()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -3,31 +3,51 @@ package testserver
 import (
 	"net/http"
 	"net/http/httptest"
+	"testing"
 )
 
 // Server is a wrapper for a test server.
 type Server struct {
+	t            *testing.T
 	s            *httptest.Server
 	RequestCount int
 	HandlerFunc  http.HandlerFunc
 }
 
-// New returns a new unstarted Server
-func New() *Server {
-	s := &Server{}
+// New returns a new, already-started Server for use in t.
+func New(t *testing.T) *Server {
+	s := &Server{t: t}
+	s.Start()
+	return s
+}
+
+// NewTLS returns a new, already-started TLS Server for use in t.
+func NewTLS(t *testing.T) *Server {
+	s := &Server{t: t}
+	s.StartTLS()
 	return s
 }
 
 // Start starts the server and sets the response to login success response
 func (s *Server) Start() {
+	s.start(httptest.NewServer)
+}
+
+// StartTLS is the TLS variant of Start, for exercising session/restclient
+// options like WithTLSConfig and WithInsecureSkipVerify.
+func (s *Server) StartTLS() {
+	s.start(httptest.NewTLSServer)
+}
+
+func (s *Server) start(newServer func(http.Handler) *httptest.Server) {
 	// already started
 	if s.s != nil {
 		return
 	}
 	// reset counter and handler
 	s.RequestCount = 0
-	s.HandlerFunc = StaticJSONHandler(map[string]string{"message": "hello world"}, http.StatusOK)
-	s.s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	s.HandlerFunc = StaticJSONHandler(s.t, map[string]string{"message": "hello world"}, http.StatusOK)
+	s.s = newServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		s.RequestCount++
 		s.HandlerFunc(w, r)
 	}))
@@ -56,4 +76,4 @@ func (s *Server) Client() *http.Client {
 // URL returns the base url of server
 func (s *Server) URL() string {
 	return s.s.URL
-}
\ No newline at end of file
+}
@@ -0,0 +1,124 @@
+package testserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// RequestValidator validates some aspect of an incoming *http.Request,
+// failing t (with msg for context) if the request doesn't match what's expected.
+type RequestValidator interface {
+	Validate(t *testing.T, msg string, r *http.Request)
+}
+
+// HeaderValidator asserts that the request has the header Key set to Value.
+type HeaderValidator struct {
+	Key   string
+	Value string
+}
+
+// Validate implements RequestValidator.
+func (v *HeaderValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	if got := r.Header.Get(v.Key); got != v.Value {
+		t.Errorf("%s: header %q = %q, want %q", msg, v.Key, got, v.Value)
+	}
+}
+
+// MethodValidator asserts that the request uses Method.
+type MethodValidator struct {
+	Method string
+}
+
+// Validate implements RequestValidator.
+func (v *MethodValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	if r.Method != v.Method {
+		t.Errorf("%s: method = %q, want %q", msg, r.Method, v.Method)
+	}
+}
+
+// PathValidator asserts that the request's URL path equals Path.
+type PathValidator struct {
+	Path string
+}
+
+// Validate implements RequestValidator.
+func (v *PathValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	if r.URL.Path != v.Path {
+		t.Errorf("%s: path = %q, want %q", msg, r.URL.Path, v.Path)
+	}
+}
+
+// QueryValidator asserts that the request's query string equals Query.
+type QueryValidator struct {
+	Query url.Values
+}
+
+// Validate implements RequestValidator.
+func (v *QueryValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	if got := r.URL.Query(); !reflect.DeepEqual(got, v.Query) {
+		t.Errorf("%s: query = %v, want %v", msg, got, v.Query)
+	}
+}
+
+// JSONBodyValidator asserts that the request body JSON-decodes to a value
+// equal to Body. A nil Body asserts that the request has no body.
+type JSONBodyValidator struct {
+	Body interface{}
+}
+
+// Validate implements RequestValidator.
+func (v *JSONBodyValidator) Validate(t *testing.T, msg string, r *http.Request) {
+	if v.Body == nil {
+		buf := make([]byte, 1)
+		if n, _ := r.Body.Read(buf); n != 0 {
+			t.Errorf("%s: expected empty request body", msg)
+		}
+		return
+	}
+
+	want, err := jsonObjectToMap(v.Body)
+	if err != nil {
+		t.Errorf("%s: failed to convert wanted body to map: %v", msg, err)
+		return
+	}
+	got := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+		t.Errorf("%s: failed to decode request body: %v", msg, err)
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: body = %v, want %v", msg, got, want)
+	}
+}
+
+// JSONResponseHandler describes a canned JSON response.
+type JSONResponseHandler struct {
+	StatusCode int
+	Body       interface{}
+}
+
+func (h *JSONResponseHandler) handle(t *testing.T, msg string, w http.ResponseWriter) {
+	w.WriteHeader(h.StatusCode)
+	if h.Body == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(h.Body); err != nil {
+		t.Errorf("%s: failed to encode response body: %v", msg, err)
+	}
+}
+
+// ValidateAndSetResponseHandler returns an http.HandlerFunc that runs every
+// validator against the incoming request, failing t on any mismatch, and
+// then responds with handler.
+func ValidateAndSetResponseHandler(t *testing.T, msg string, handler *JSONResponseHandler,
+	validators ...RequestValidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, v := range validators {
+			v.Validate(t, msg, r)
+		}
+		handler.handle(t, msg, w)
+	}
+}
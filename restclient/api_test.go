@@ -1,6 +1,7 @@
 package restclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,7 +21,11 @@ const (
 
 var (
 	loginSuccessHandler = func(w http.ResponseWriter, r *http.Request) {
-		serverURL := fmt.Sprintf("http://%s", r.Host)
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		serverURL := fmt.Sprintf("%s://%s", scheme, r.Host)
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(session.RequestToken{
 			AccessToken: accessToken,
@@ -60,7 +65,7 @@ func createClientAndServer(t *testing.T) (*Client, *testserver.Server) {
 	s.RequestCount = 0 // reset counter
 
 	// create client
-	client := &Client{sess, s.Client()}
+	client := New(sess, WithHTTPClient(s.Client()))
 
 	return client, s
 }
@@ -362,25 +367,44 @@ func TestUnauthorizedClient(t *testing.T) {
 	assert.Equal(t, 2, server.RequestCount, "expected 2 request (create and login)")
 
 	server.RequestCount = 0 // reset counter
-	// 1st request fails, 2nd returns login, other return upsert result
-	server.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
-		switch server.RequestCount {
-		case 0:
-			t.Error("request count can't be 0")
-		case 1:
-			unauthorizedHandler(w, r)
-		case 2:
-			loginSuccessHandler(w, r)
-		default:
-			testserver.StaticJSONHandler(t, UpsertResult{"id", true, nil}, http.StatusCreated)(w, r)
-		}
-	}
+	// 1st request fails, 2nd returns login, 3rd (retry) returns upsert result
+	handlerFunc, scenario := testserver.ScenarioHandler(t,
+		testserver.Step{Handler: unauthorizedHandler},
+		testserver.Step{Handler: loginSuccessHandler},
+		testserver.Step{Handler: testserver.StaticJSONHandler(t, UpsertResult{"id", true, nil}, http.StatusCreated)},
+	)
+	server.HandlerFunc = handlerFunc
 	_, err = client.CreateSObject("Object", map[string]interface{}{"A": "B"})
 	assert.Nil(t, err, "client request should've succeeded")
+	assert.Equal(t, 0, scenario.Remaining(), "expected every scripted step to be hit")
 	// 3 requests (create POST and login POST and retry create POST)
 	assert.Equal(t, 3, server.RequestCount, "expected 3 requests (create, login, retry)")
 }
 
+func TestRequestIDPropagation(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	// 1st request (create) fails with 401, 2nd (login) succeeds, 3rd (retry create) succeeds,
+	// all carrying the same request ID.
+	idValidator := &testserver.RequestIDValidator{}
+	handlerFunc, scenario := testserver.ScenarioHandler(t,
+		testserver.Step{Validators: []testserver.RequestValidator{idValidator}, Handler: unauthorizedHandler},
+		testserver.Step{Validators: []testserver.RequestValidator{idValidator}, Handler: loginSuccessHandler},
+		testserver.Step{
+			Validators: []testserver.RequestValidator{idValidator},
+			Handler:    testserver.StaticJSONHandler(t, UpsertResult{"id", true, nil}, http.StatusCreated),
+		},
+	)
+	server.HandlerFunc = handlerFunc
+
+	ctx := WithRequestID(context.Background(), "11111111-1111-1111-1111-111111111111")
+	_, err := client.CreateSObjectContext(ctx, "Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err, "client request should've succeeded")
+	assert.Equal(t, 0, scenario.Remaining(), "expected every scripted step to be hit")
+	assert.Equal(t, 3, server.RequestCount, "expected 3 requests (create, login, retry)")
+}
+
 func assertRequest(t *testing.T, assertMsg string, server *testserver.Server, wantErr string,
 	invokeFunc func() (interface{}, error), successFunc func(interface{}),
 	expectedRequestCount int, validators []testserver.RequestValidator,
@@ -0,0 +1,256 @@
+// Package restclient implements a REST client for the Salesforce REST API.
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Laugusti/go-sforce/internal/requestid"
+	"github.com/Laugusti/go-sforce/session"
+)
+
+// Client is a REST client for the Salesforce REST API.
+type Client struct {
+	session    *session.Session
+	httpClient *http.Client
+	middleware []Middleware
+}
+
+// New returns a new Client that issues requests against sess. By default,
+// requests are made with http.DefaultClient, wrapped in ReauthOnUnauthorized
+// so an expired session is transparently refreshed; use WithHTTPClient,
+// WithTransport, WithTLSConfig, WithInsecureSkipVerify or WithMiddleware to
+// override that.
+func New(sess *session.Session, opts ...Option) *Client {
+	c := &Client{
+		session:    sess,
+		httpClient: http.DefaultClient,
+		middleware: []Middleware{ReauthOnUnauthorized()},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) basePath() string {
+	return fmt.Sprintf("%s/services/data/%s", c.session.InstanceURL, c.session.APIVersion)
+}
+
+// doRequest issues an HTTP request against path with the given method and
+// JSON-encoded body (if any), running it through the Client's middleware
+// chain. The request ID carried by ctx (or a newly generated one, if ctx
+// doesn't carry one) is sent as the X-Request-ID header on the request, any
+// retry a middleware makes, and the login call ReauthOnUnauthorized issues,
+// so the whole chain can be correlated in Salesforce's server logs.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	id := requestid.FromContextOrNew(ctx)
+	ctx = requestid.WithContext(ctx, id)
+	ctx = withReauth(ctx, func(ctx context.Context) (string, error) {
+		if err := c.session.LoginContext(ctx); err != nil {
+			return "", err
+		}
+		return "Bearer " + c.session.AccessToken, nil
+	})
+
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.chain(ctx)(req)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("restclient: failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("restclient: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.session.AccessToken)
+	req.Header.Set(requestid.Header, requestid.FromContextOrNew(ctx))
+
+	return req, nil
+}
+
+// doAndDecode issues the request and, on success, decodes the response body
+// into respBody (skipped if respBody is nil). A non-2xx response is decoded
+// as an APIError and returned as the error.
+func (c *Client) doAndDecode(ctx context.Context, method, path string, reqBody, respBody interface{}) error {
+	resp, err := c.doRequest(ctx, method, path, reqBody)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		var apiErr APIError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			return fmt.Errorf("restclient: failed to decode error response: %w", err)
+		}
+		return apiErr
+	}
+	if respBody == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(respBody)
+}
+
+// CreateSObject creates a new SObject of the given type and returns the result.
+func (c *Client) CreateSObject(objectType string, object SObject) (*UpsertResult, error) {
+	return c.CreateSObjectContext(context.Background(), objectType, object)
+}
+
+// CreateSObjectContext is the context-aware variant of CreateSObject.
+func (c *Client) CreateSObjectContext(ctx context.Context, objectType string, object SObject) (*UpsertResult, error) {
+	if objectType == "" {
+		return nil, errors.New("restclient: sobject name is required")
+	}
+	if len(object) == 0 {
+		return nil, errors.New("restclient: sobject value is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s", c.basePath(), objectType)
+	var result UpsertResult
+	if err := c.doAndDecode(ctx, http.MethodPost, path, object, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSObject fetches the SObject of the given type and id.
+func (c *Client) GetSObject(objectType, id string) (SObject, error) {
+	return c.GetSObjectContext(context.Background(), objectType, id)
+}
+
+// GetSObjectContext is the context-aware variant of GetSObject.
+func (c *Client) GetSObjectContext(ctx context.Context, objectType, id string) (SObject, error) {
+	if objectType == "" {
+		return nil, errors.New("restclient: sobject name is required")
+	}
+	if id == "" {
+		return nil, errors.New("restclient: sobject id is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s/%s", c.basePath(), objectType, id)
+	var result SObject
+	if err := c.doAndDecode(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSObjectByExternalID fetches the SObject of the given type whose
+// externalIDField equals externalID.
+func (c *Client) GetSObjectByExternalID(objectType, externalIDField, externalID string) (SObject, error) {
+	return c.GetSObjectByExternalIDContext(context.Background(), objectType, externalIDField, externalID)
+}
+
+// GetSObjectByExternalIDContext is the context-aware variant of GetSObjectByExternalID.
+func (c *Client) GetSObjectByExternalIDContext(ctx context.Context, objectType, externalIDField,
+	externalID string) (SObject, error) {
+	if objectType == "" {
+		return nil, errors.New("restclient: sobject name is required")
+	}
+	if externalIDField == "" {
+		return nil, errors.New("restclient: external id field is required")
+	}
+	if externalID == "" {
+		return nil, errors.New("restclient: external id is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s/%s/%s", c.basePath(), objectType, externalIDField, externalID)
+	var result SObject
+	if err := c.doAndDecode(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpsertSObject creates or updates the SObject of the given type and id.
+func (c *Client) UpsertSObject(objectType, id string, object SObject) (*UpsertResult, error) {
+	return c.UpsertSObjectContext(context.Background(), objectType, id, object)
+}
+
+// UpsertSObjectContext is the context-aware variant of UpsertSObject.
+func (c *Client) UpsertSObjectContext(ctx context.Context, objectType, id string,
+	object SObject) (*UpsertResult, error) {
+	if objectType == "" {
+		return nil, errors.New("restclient: sobject name is required")
+	}
+	if id == "" {
+		return nil, errors.New("restclient: sobject id is required")
+	}
+	if len(object) == 0 {
+		return nil, errors.New("restclient: sobject value is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s/%s", c.basePath(), objectType, id)
+	var result UpsertResult
+	if err := c.doAndDecode(ctx, http.MethodPatch, path, object, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpsertSObjectByExternalID creates or updates the SObject of the given type
+// whose externalIDField equals externalID.
+func (c *Client) UpsertSObjectByExternalID(objectType, externalIDField, externalID string,
+	object SObject) (*UpsertResult, error) {
+	return c.UpsertSObjectByExternalIDContext(context.Background(), objectType, externalIDField, externalID, object)
+}
+
+// UpsertSObjectByExternalIDContext is the context-aware variant of UpsertSObjectByExternalID.
+func (c *Client) UpsertSObjectByExternalIDContext(ctx context.Context, objectType, externalIDField,
+	externalID string, object SObject) (*UpsertResult, error) {
+	if objectType == "" {
+		return nil, errors.New("restclient: sobject name is required")
+	}
+	if externalIDField == "" {
+		return nil, errors.New("restclient: external id field is required")
+	}
+	if externalID == "" {
+		return nil, errors.New("restclient: external id is required")
+	}
+	if len(object) == 0 {
+		return nil, errors.New("restclient: sobject value is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s/%s/%s", c.basePath(), objectType, externalIDField, externalID)
+	var result UpsertResult
+	if err := c.doAndDecode(ctx, http.MethodPatch, path, object, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSObject deletes the SObject of the given type and id.
+func (c *Client) DeleteSObject(objectType, id string) error {
+	return c.DeleteSObjectContext(context.Background(), objectType, id)
+}
+
+// DeleteSObjectContext is the context-aware variant of DeleteSObject.
+func (c *Client) DeleteSObjectContext(ctx context.Context, objectType, id string) error {
+	if objectType == "" {
+		return errors.New("restclient: sobject name is required")
+	}
+	if id == "" {
+		return errors.New("restclient: sobject id is required")
+	}
+
+	path := fmt.Sprintf("%s/sobjects/%s/%s", c.basePath(), objectType, id)
+	return c.doAndDecode(ctx, http.MethodDelete, path, nil, nil)
+}
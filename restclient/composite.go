@@ -0,0 +1,139 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Ref returns a placeholder, e.g. "@{refName.id}", that a later
+// CompositeSubRequest.Body can use to reference a field of an earlier
+// sub-request's result, named by its ReferenceID. Salesforce
+// resolves the placeholder when it executes the composite request.
+func Ref(referenceID, field string) string {
+	return fmt.Sprintf("@{%s.%s}", referenceID, field)
+}
+
+// CompositeSubRequest is a single request within a CompositeRequest.
+type CompositeSubRequest struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	ReferenceID string      `json:"referenceId"`
+	Body        interface{} `json:"body,omitempty"`
+}
+
+// CompositeRequest is a request to the Composite REST resource: a sequence
+// of sub-requests executed in order and, unless AllOrNone is set, isolated
+// from each other's failures. A later sub-request's Body can reference an
+// earlier one's result with Ref.
+type CompositeRequest struct {
+	AllOrNone        bool                  `json:"allOrNone"`
+	CompositeRequest []CompositeSubRequest `json:"compositeRequest"`
+}
+
+// CompositeSubResponse is a single response within a CompositeResponse.
+type CompositeSubResponse struct {
+	Body           json.RawMessage `json:"body"`
+	HTTPStatusCode int             `json:"httpStatusCode"`
+	ReferenceID    string          `json:"referenceId"`
+}
+
+// Error returns the sub-response's body decoded as an APIError if
+// HTTPStatusCode indicates failure, or nil otherwise.
+func (r CompositeSubResponse) Error() error {
+	if r.HTTPStatusCode < 300 {
+		return nil
+	}
+	var errs []APIError
+	if err := json.Unmarshal(r.Body, &errs); err != nil || len(errs) == 0 {
+		return fmt.Errorf("restclient: sub-request %q failed with status %d", r.ReferenceID, r.HTTPStatusCode)
+	}
+	return errs[0]
+}
+
+// CompositeResponse is the response to a CompositeRequest, one
+// CompositeSubResponse per sub-request, in the same order.
+type CompositeResponse struct {
+	CompositeResponse []CompositeSubResponse `json:"compositeResponse"`
+}
+
+// Composite executes req's sub-requests as a single Composite REST call.
+func (c *Client) Composite(req *CompositeRequest) (*CompositeResponse, error) {
+	return c.CompositeContext(context.Background(), req)
+}
+
+// CompositeContext is the context-aware variant of Composite.
+func (c *Client) CompositeContext(ctx context.Context, req *CompositeRequest) (*CompositeResponse, error) {
+	if req == nil || len(req.CompositeRequest) == 0 {
+		return nil, errors.New("restclient: composite request is required")
+	}
+
+	path := fmt.Sprintf("%s/composite", c.basePath())
+	var result CompositeResponse
+	if err := c.doAndDecode(ctx, http.MethodPost, path, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CompositeBatchSubRequest is a single request within a CompositeBatchRequest.
+type CompositeBatchSubRequest struct {
+	Method    string      `json:"method"`
+	URL       string      `json:"url"`
+	RichInput interface{} `json:"richInput,omitempty"`
+}
+
+// CompositeBatchRequest is a request to the Composite Batch REST resource: a
+// sequence of independent sub-requests executed in order.
+type CompositeBatchRequest struct {
+	HaltOnError   bool                       `json:"haltOnError"`
+	BatchRequests []CompositeBatchSubRequest `json:"batchRequests"`
+}
+
+// CompositeBatchSubResponse is a single response within a CompositeBatchResponse.
+type CompositeBatchSubResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// Error returns the sub-response's result decoded as an APIError if
+// StatusCode indicates failure, or nil otherwise.
+func (r CompositeBatchSubResponse) Error() error {
+	if r.StatusCode < 300 {
+		return nil
+	}
+	var errs []APIError
+	if err := json.Unmarshal(r.Result, &errs); err != nil || len(errs) == 0 {
+		return fmt.Errorf("restclient: batch sub-request failed with status %d", r.StatusCode)
+	}
+	return errs[0]
+}
+
+// CompositeBatchResponse is the response to a CompositeBatchRequest, one
+// CompositeBatchSubResponse per sub-request, in the same order.
+type CompositeBatchResponse struct {
+	HasErrors bool                        `json:"hasErrors"`
+	Results   []CompositeBatchSubResponse `json:"results"`
+}
+
+// CompositeBatch executes req's sub-requests as a single Composite Batch
+// REST call.
+func (c *Client) CompositeBatch(req *CompositeBatchRequest) (*CompositeBatchResponse, error) {
+	return c.CompositeBatchContext(context.Background(), req)
+}
+
+// CompositeBatchContext is the context-aware variant of CompositeBatch.
+func (c *Client) CompositeBatchContext(ctx context.Context, req *CompositeBatchRequest) (*CompositeBatchResponse, error) {
+	if req == nil || len(req.BatchRequests) == 0 {
+		return nil, errors.New("restclient: composite batch request is required")
+	}
+
+	path := fmt.Sprintf("%s/composite/batch", c.basePath())
+	var result CompositeBatchResponse
+	if err := c.doAndDecode(ctx, http.MethodPost, path, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,77 @@
+package restclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposite(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	path := fmt.Sprintf("/services/data/%s/composite", apiVersion)
+	server.HandlerFunc = testserver.CompositeHandler(t, "composite", path, []testserver.CompositeSubResponseScript{
+		{StatusCode: 201, Body: map[string]interface{}{"id": "001", "success": true, "errors": []interface{}{}}},
+		{StatusCode: 400, Body: []interface{}{map[string]interface{}{"message": "bad", "errorCode": "GENERIC_ERROR"}}},
+	})
+
+	req := &CompositeRequest{
+		CompositeRequest: []CompositeSubRequest{
+			{
+				Method:      "POST",
+				URL:         fmt.Sprintf("/services/data/%s/sobjects/Account", apiVersion),
+				ReferenceID: "NewAccount",
+				Body:        map[string]interface{}{"Name": "Acme"},
+			},
+			{
+				Method:      "POST",
+				URL:         fmt.Sprintf("/services/data/%s/sobjects/Contact", apiVersion),
+				ReferenceID: "NewContact",
+				Body:        map[string]interface{}{"AccountId": Ref("NewAccount", "id")},
+			},
+		},
+	}
+	res, err := client.Composite(req)
+	assert.Nil(t, err)
+	if assert.Len(t, res.CompositeResponse, 2) {
+		assert.Nil(t, res.CompositeResponse[0].Error())
+		assert.Equal(t, "NewAccount", res.CompositeResponse[0].ReferenceID)
+
+		err := res.CompositeResponse[1].Error()
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "GENERIC_ERROR")
+		}
+	}
+}
+
+func TestComposite_RequiresSubRequests(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	_, err := client.Composite(&CompositeRequest{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "composite request is required")
+	assert.Equal(t, 0, server.RequestCount)
+}
+
+func TestCompositeBatch(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	path := fmt.Sprintf("/services/data/%s/composite/batch", apiVersion)
+	server.HandlerFunc = testserver.CompositeHandler(t, "composite batch", path, []testserver.CompositeSubResponseScript{
+		{StatusCode: 200, Body: map[string]interface{}{"Id": "001"}},
+	})
+
+	req := &CompositeBatchRequest{
+		BatchRequests: []CompositeBatchSubRequest{
+			{Method: "GET", URL: fmt.Sprintf("/services/data/%s/sobjects/Account/001", apiVersion)},
+		},
+	}
+	res, err := client.CompositeBatch(req)
+	assert.Nil(t, err)
+	assert.Len(t, res.Results, 1)
+}
@@ -0,0 +1,27 @@
+package restclient
+
+import (
+	"context"
+
+	"github.com/Laugusti/go-sforce/internal/requestid"
+)
+
+// RequestIDHeader is the HTTP header used to propagate a request ID to Salesforce.
+const RequestIDHeader = requestid.Header
+
+// WithRequestID returns a copy of ctx carrying the given request ID. The ID
+// is sent as the X-Request-ID header on every outbound call made with ctx,
+// including the retry after a 401 and the login call it triggers, so a
+// single ID ties the whole chain together in Salesforce's server logs.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return requestid.WithContext(ctx, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" if ctx
+// wasn't seeded with WithRequestID. It's a plain read: it doesn't generate
+// or store an ID, so if ctx doesn't carry one, calls made with ctx get one
+// minted internally rather than one predictable from this function.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := requestid.FromContext(ctx)
+	return id
+}
@@ -0,0 +1,14 @@
+package restclient
+
+import "fmt"
+
+// APIError represents an error response from the Salesforce REST API.
+type APIError struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"errorCode"`
+}
+
+// Error implements the error interface.
+func (e APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
+}
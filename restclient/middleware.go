@@ -0,0 +1,106 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RoundTripFunc matches http.RoundTripper's RoundTrip method, letting a
+// Middleware be written as a plain function instead of a full interface.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc so cross-cutting behavior -- logging,
+// metrics, retries, request-body redaction, tracing -- can be layered around
+// every call a Client makes, without forking the client.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middleware to the end of the Client's chain, closest to the
+// actual HTTP call: middleware already on the Client (including the default
+// ReauthOnUnauthorized installed by New) sees the request first and the
+// response last.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// chain builds the RoundTripFunc that actually executes a request, wrapped
+// with the Client's middleware, or with the override installed by
+// WithCallMiddleware on ctx, if any.
+func (c *Client) chain(ctx context.Context) RoundTripFunc {
+	middleware := c.middleware
+	if override, ok := middlewareFromContext(ctx); ok {
+		middleware = override
+	}
+
+	rt := RoundTripFunc(c.httpClient.Do)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
+
+type middlewareContextKey struct{}
+
+// WithCallMiddleware returns a copy of ctx that replaces the Client's
+// middleware chain with middleware for any call made with ctx. Use it to
+// override the chain set at construction (via WithMiddleware) for a single
+// call.
+func WithCallMiddleware(ctx context.Context, middleware ...Middleware) context.Context {
+	return context.WithValue(ctx, middlewareContextKey{}, middleware)
+}
+
+func middlewareFromContext(ctx context.Context) ([]Middleware, bool) {
+	m, ok := ctx.Value(middlewareContextKey{}).([]Middleware)
+	return m, ok
+}
+
+type reauthContextKey struct{}
+
+// reauthFunc logs in again and returns the Authorization header value to use
+// for the retried request.
+type reauthFunc func(ctx context.Context) (string, error)
+
+func withReauth(ctx context.Context, fn reauthFunc) context.Context {
+	return context.WithValue(ctx, reauthContextKey{}, fn)
+}
+
+func reauthFromContext(ctx context.Context) (reauthFunc, bool) {
+	fn, ok := ctx.Value(reauthContextKey{}).(reauthFunc)
+	return fn, ok
+}
+
+// ReauthOnUnauthorized returns a Middleware that, on a 401 response, logs in
+// again and retries the request once with the refreshed Authorization
+// header. It's included by default in the middleware chain built by New.
+func ReauthOnUnauthorized() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			reauth, ok := reauthFromContext(req.Context())
+			if !ok {
+				return resp, err
+			}
+			_ = resp.Body.Close()
+
+			authHeader, err := reauth(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("restclient: re-login after unauthorized response: %w", err)
+			}
+
+			retryReq := req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("restclient: failed to rewind request body for retry: %w", err)
+				}
+				retryReq.Body = body
+			}
+			retryReq.Header.Set("Authorization", authHeader)
+			return next(retryReq)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package restclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Laugusti/go-sforce/credentials"
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/Laugusti/go-sforce/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	client.Use(RetryWithBackoff(BackoffPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	// 1st and 2nd attempts return a 429, 3rd succeeds.
+	server.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		if server.RequestCount < 3 {
+			w.Header().Set("Sforce-Limit-Info", "api-usage=100/20000")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		testserver.StaticJSONHandler(t, UpsertResult{"id", true, nil}, http.StatusCreated)(w, r)
+	}
+
+	res, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err, "request should've succeeded after retries")
+	if assert.NotNil(t, res) {
+		assert.True(t, res.Success)
+	}
+	assert.Equal(t, 3, server.RequestCount, "expected 2 failed attempts and 1 success")
+}
+
+func TestRetryWithBackoffExhausted(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	client.Use(RetryWithBackoff(BackoffPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+
+	server.HandlerFunc = testserver.StaticJSONHandler(t, APIError{Message: "down", ErrorCode: "SERVER_UNAVAILABLE"},
+		http.StatusServiceUnavailable)
+
+	_, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.NotNil(t, err, "expected error once attempts are exhausted")
+	assert.Equal(t, 2, server.RequestCount, "expected exactly policy.MaxAttempts requests")
+}
+
+func TestWithMiddleware(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+
+	s.HandlerFunc = loginSuccessHandler
+	sess := session.Must(session.New(s.URL(), apiVersion, credentials.New("user", "pass", "cid", "csecret")))
+	assert.Nil(t, sess.Login())
+	s.RequestCount = 0 // reset counter
+
+	var calls int
+	recordingMiddleware := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			calls++
+			return next(req)
+		}
+	}
+	// WithMiddleware replaces the default chain entirely, so the 401
+	// response below is never retried: ReauthOnUnauthorized isn't in it.
+	client := New(sess, WithHTTPClient(s.Client()), WithMiddleware(recordingMiddleware))
+
+	s.HandlerFunc = unauthorizedHandler
+	_, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.NotNil(t, err, "expected the 401 to surface since the default chain was replaced")
+	assert.Equal(t, 1, calls, "expected the custom middleware to run")
+	assert.Equal(t, 1, s.RequestCount, "expected no reauth retry: WithMiddleware replaced the default chain")
+}
+
+func TestWithCallMiddleware(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	var calls int
+	recordingMiddleware := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			calls++
+			return next(req)
+		}
+	}
+
+	// With the call-scoped chain in effect (no ReauthOnUnauthorized), the
+	// 401 below is never retried.
+	server.HandlerFunc = unauthorizedHandler
+	ctx := WithCallMiddleware(context.Background(), recordingMiddleware)
+	_, err := client.CreateSObjectContext(ctx, "Object", map[string]interface{}{"A": "B"})
+	assert.NotNil(t, err, "expected the 401 to surface since WithCallMiddleware overrode the default chain")
+	assert.Equal(t, 1, calls, "expected the call-scoped middleware to run")
+	assert.Equal(t, 1, server.RequestCount, "expected no reauth retry: WithCallMiddleware overrode the constructor chain")
+
+	// Without the context override, the constructor's default chain
+	// (ReauthOnUnauthorized) is back in effect and does retry.
+	server.RequestCount = 0
+	handlerFunc, scenario := testserver.ScenarioHandler(t,
+		testserver.Step{Handler: unauthorizedHandler},
+		testserver.Step{Handler: loginSuccessHandler},
+		testserver.Step{Handler: testserver.StaticJSONHandler(t, UpsertResult{"id", true, nil}, http.StatusCreated)},
+	)
+	server.HandlerFunc = handlerFunc
+	_, err = client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err, "expected the constructor's default chain to retry after reauth")
+	assert.Equal(t, 0, scenario.Remaining(), "expected every scripted step to be hit")
+}
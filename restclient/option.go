@@ -0,0 +1,81 @@
+package restclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/Laugusti/go-sforce/internal/httputil"
+)
+
+// Option configures a Client constructed by New.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to issue requests, overriding the
+// default of http.DefaultClient. Options are applied in order: a
+// WithTransport, WithTLSConfig, WithProxy or WithInsecureSkipVerify given
+// before it is discarded, and one given after it modifies c itself.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) {
+		cl.httpClient = c
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by the Client's http.Client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(cl *Client) {
+		cl.transportClient().Transport = rt
+	}
+}
+
+// WithTLSConfig sets the tls.Config used by the Client's http.Client, for
+// connecting to Salesforce Government Cloud or through a corporate proxy
+// that terminates TLS with a private CA.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(cl *Client) {
+		cl.transport().TLSClientConfig = cfg
+	}
+}
+
+// WithProxy routes the Client's requests through the given proxy URL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(cl *Client) {
+		cl.transport().Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the
+// Client's http.Client. It's meant for sandbox testing against servers with
+// self-signed certificates, never for production use.
+func WithInsecureSkipVerify() Option {
+	return func(cl *Client) {
+		t := cl.transport()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.InsecureSkipVerify = true
+	}
+}
+
+// WithMiddleware replaces the Client's middleware chain, overriding the
+// default of []Middleware{ReauthOnUnauthorized()} set by New. Include
+// ReauthOnUnauthorized() in the list yourself if you still want it alongside
+// your own middleware. Use WithCallMiddleware to override the chain for a
+// single call instead of for the whole Client.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(cl *Client) {
+		cl.middleware = middleware
+	}
+}
+
+// transportClient returns c.httpClient, allocating one if it's still the
+// package default.
+func (c *Client) transportClient() *http.Client {
+	return httputil.TransportClient(&c.httpClient)
+}
+
+// transport returns the *http.Transport backing c.httpClient, cloning
+// http.DefaultTransport into it if it isn't one already.
+func (c *Client) transport() *http.Transport {
+	return httputil.Transport(&c.httpClient)
+}
@@ -0,0 +1,108 @@
+package restclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/credentials"
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/Laugusti/go-sforce/session"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestInsecureSkipVerifyOption(t *testing.T) {
+	s := testserver.NewTLS(t)
+	defer s.Stop()
+
+	// login over TLS using a self-signed cert requires InsecureSkipVerify
+	s.HandlerFunc = loginSuccessHandler
+	sess, err := session.New(s.URL(), apiVersion, credentials.New("user", "pass", "cid", "csecret"),
+		session.WithInsecureSkipVerify())
+	assert.Nil(t, err)
+	assert.Nil(t, sess.Login(), "login over TLS should've succeeded")
+	assert.Equal(t, 1, s.RequestCount)
+	s.RequestCount = 0 // reset counter
+
+	// client created with its own InsecureSkipVerify option should also be able to talk to the server
+	client := New(sess, WithInsecureSkipVerify())
+	s.HandlerFunc = testserver.ValidateAndSetResponseHandler(t, "create over TLS",
+		&testserver.JSONResponseHandler{StatusCode: 201, Body: UpsertResult{ID: "id", Success: true, Errors: []interface{}{}}},
+		authTokenValidator)
+	res, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err, "create over TLS should've succeeded")
+	if assert.NotNil(t, res) {
+		assert.True(t, res.Success)
+	}
+}
+
+func TestWithTransportOption(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+
+	s.HandlerFunc = loginSuccessHandler
+	sess := session.Must(session.New(s.URL(), apiVersion, credentials.New("user", "pass", "cid", "csecret")))
+	assert.Nil(t, sess.Login())
+	s.RequestCount = 0 // reset counter
+
+	var calls int
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := New(sess, WithTransport(rt))
+
+	s.HandlerFunc = testserver.ValidateAndSetResponseHandler(t, "create with custom transport",
+		&testserver.JSONResponseHandler{StatusCode: 201, Body: UpsertResult{ID: "id", Success: true, Errors: []interface{}{}}},
+		authTokenValidator)
+	_, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls, "expected the request to go through the custom transport")
+}
+
+func TestWithTLSConfigOption(t *testing.T) {
+	s := testserver.NewTLS(t)
+	defer s.Stop()
+
+	s.HandlerFunc = loginSuccessHandler
+	sess := session.Must(session.New(s.URL(), apiVersion, credentials.New("user", "pass", "cid", "csecret"),
+		session.WithInsecureSkipVerify()))
+	assert.Nil(t, sess.Login(), "login should've succeeded")
+	s.RequestCount = 0 // reset counter
+
+	// a custom tls.Config that skips verification proves WithTLSConfig
+	// actually wires the config into the http.Client, independent of the
+	// dedicated WithInsecureSkipVerify option.
+	client := New(sess, WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+	s.HandlerFunc = testserver.ValidateAndSetResponseHandler(t, "create over TLS",
+		&testserver.JSONResponseHandler{StatusCode: 201, Body: UpsertResult{ID: "id", Success: true, Errors: []interface{}{}}},
+		authTokenValidator)
+	res, err := client.CreateSObject("Object", map[string]interface{}{"A": "B"})
+	assert.Nil(t, err, "create over TLS should've succeeded with the custom tls.Config")
+	if assert.NotNil(t, res) {
+		assert.True(t, res.Success)
+	}
+}
+
+func TestWithProxyOption(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.example.com:8080")
+	assert.Nil(t, err)
+
+	sess := session.Must(session.New("https://login.salesforce.com", apiVersion,
+		credentials.New("user", "pass", "cid", "csecret")))
+	client := New(sess, WithProxy(proxyURL))
+
+	req, err := http.NewRequest(http.MethodPost, "https://instance.salesforce.com/services/data/mock/sobjects/Object", nil)
+	assert.Nil(t, err)
+	got, err := client.transport().Proxy(req)
+	assert.Nil(t, err)
+	assert.Equal(t, proxyURL, got, "expected the request to be routed through the configured proxy")
+}
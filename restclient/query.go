@@ -0,0 +1,125 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// QueryResult is a page of results from the Query REST resource.
+type QueryResult struct {
+	TotalSize      int       `json:"totalSize"`
+	Done           bool      `json:"done"`
+	NextRecordsURL string    `json:"nextRecordsUrl"`
+	Records        []SObject `json:"records"`
+}
+
+// Query runs soql against the Query REST resource and returns the first
+// page of results. Use QueryIterator to transparently page through every
+// result instead of handling NextRecordsURL yourself.
+func (c *Client) Query(soql string) (*QueryResult, error) {
+	return c.QueryContext(context.Background(), soql)
+}
+
+// QueryContext is the context-aware variant of Query.
+func (c *Client) QueryContext(ctx context.Context, soql string) (*QueryResult, error) {
+	return c.query(ctx, "query", soql)
+}
+
+// QueryAll is like Query, but also returns records that have been deleted or
+// archived, via the queryAll REST resource.
+func (c *Client) QueryAll(soql string) (*QueryResult, error) {
+	return c.QueryAllContext(context.Background(), soql)
+}
+
+// QueryAllContext is the context-aware variant of QueryAll.
+func (c *Client) QueryAllContext(ctx context.Context, soql string) (*QueryResult, error) {
+	return c.query(ctx, "queryAll", soql)
+}
+
+func (c *Client) query(ctx context.Context, resource, soql string) (*QueryResult, error) {
+	if soql == "" {
+		return nil, errors.New("restclient: soql query is required")
+	}
+
+	path := fmt.Sprintf("%s/%s?%s", c.basePath(), resource, url.Values{"q": []string{soql}}.Encode())
+	var result QueryResult
+	if err := c.doAndDecode(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// nextRecords fetches the page of query results at nextRecordsURL, as
+// returned in a prior QueryResult.
+func (c *Client) nextRecords(ctx context.Context, nextRecordsURL string) (*QueryResult, error) {
+	path := c.session.InstanceURL + nextRecordsURL
+	var result QueryResult
+	if err := c.doAndDecode(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// QueryIterator iterates over every record matching a SOQL query,
+// transparently fetching subsequent pages by following NextRecordsURL. Use
+// QueryIterator (or QueryIteratorContext) to create one, Next to advance it,
+// Record to read the current record, and Err to check for a fetch error
+// once Next returns false.
+type QueryIterator struct {
+	client  *Client
+	ctx     context.Context
+	soql    string
+	started bool
+	page    *QueryResult
+	index   int
+	err     error
+}
+
+// QueryIterator returns an iterator over every record matching soql.
+func (c *Client) QueryIterator(soql string) *QueryIterator {
+	return c.QueryIteratorContext(context.Background(), soql)
+}
+
+// QueryIteratorContext is the context-aware variant of QueryIterator.
+func (c *Client) QueryIteratorContext(ctx context.Context, soql string) *QueryIterator {
+	return &QueryIterator{client: c, ctx: ctx, soql: soql}
+}
+
+// Next advances the iterator to the next record, fetching subsequent pages
+// as needed. It returns false once there are no more records or a fetch
+// failed; call Err to tell the two apart.
+func (it *QueryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		it.page, it.err = it.client.query(it.ctx, "query", it.soql)
+		it.index = -1
+	}
+	it.index++
+
+	for it.err == nil && it.page != nil && it.index >= len(it.page.Records) {
+		if it.page.Done || it.page.NextRecordsURL == "" {
+			return false
+		}
+		it.page, it.err = it.client.nextRecords(it.ctx, it.page.NextRecordsURL)
+		it.index = 0
+	}
+	return it.err == nil && it.page != nil && it.index < len(it.page.Records)
+}
+
+// Record returns the record Next just advanced to. Call it only after a
+// call to Next returns true.
+func (it *QueryIterator) Record() SObject {
+	return it.page.Records[it.index]
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *QueryIterator) Err() error {
+	return it.err
+}
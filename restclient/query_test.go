@@ -0,0 +1,66 @@
+package restclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	soql := "SELECT Id FROM Object"
+	path := fmt.Sprintf("/services/data/%s/query", apiVersion)
+	records := []interface{}{
+		map[string]interface{}{"Id": "1"},
+		map[string]interface{}{"Id": "2"},
+	}
+	server.HandlerFunc = testserver.QueryHandler(t, "query", path, soql, records, 10)
+
+	res, err := client.Query(soql)
+	assert.Nil(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, 2, res.TotalSize)
+		assert.True(t, res.Done)
+		assert.Equal(t, "", res.NextRecordsURL)
+		assert.Len(t, res.Records, 2)
+	}
+}
+
+func TestQuery_RequiresSOQL(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	_, err := client.Query("")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "soql query is required")
+	assert.Equal(t, 0, server.RequestCount)
+}
+
+func TestQueryIterator(t *testing.T) {
+	client, server := createClientAndServer(t)
+	defer server.Stop()
+
+	soql := "SELECT Id FROM Object"
+	path := fmt.Sprintf("/services/data/%s/query", apiVersion)
+	records := []interface{}{
+		map[string]interface{}{"Id": "1"},
+		map[string]interface{}{"Id": "2"},
+		map[string]interface{}{"Id": "3"},
+	}
+	// page size of 2 forces the iterator to follow nextRecordsUrl once
+	server.HandlerFunc = testserver.QueryHandler(t, "query iterator", path, soql, records, 2)
+
+	it := client.QueryIterator(soql)
+	var got []SObject
+	for it.Next() {
+		got = append(got, it.Record())
+	}
+	assert.Nil(t, it.Err())
+	assert.Len(t, got, 3)
+	assert.Equal(t, "1", got[0]["Id"])
+	assert.Equal(t, "3", got[2]["Id"])
+}
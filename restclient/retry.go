@@ -0,0 +1,119 @@
+package restclient
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sforceLimitInfoHeader reports an org's daily API usage, e.g.
+// "api-usage=18000/20000".
+const sforceLimitInfoHeader = "Sforce-Limit-Info"
+
+// BackoffPolicy configures RetryWithBackoff.
+type BackoffPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. Values less than 1 are treated as 1 (no
+	// retries).
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; each subsequent retry
+	// doubles it before jitter is applied.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay, before jitter.
+	MaxDelay time.Duration
+}
+
+// RetryWithBackoff returns a Middleware that retries responses with status
+// 429 (Too Many Requests) or 5xx using exponential backoff with jitter, up
+// to policy.MaxAttempts attempts total. If the response carries Salesforce's
+// Sforce-Limit-Info header and it reports the org's daily API usage is over
+// 90%, the backoff for that attempt is doubled to give the limit a chance to
+// reset before the next try.
+func RetryWithBackoff(policy BackoffPolicy) Middleware {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var resp *http.Response
+			var err error
+			for attempt := 0; attempt < maxAttempts; attempt++ {
+				resp, err = next(req)
+				if err != nil || !isRetryableResponse(resp) || attempt == maxAttempts-1 {
+					return resp, err
+				}
+
+				delay := backoffDelay(policy, attempt)
+				if nearAPILimit(resp.Header.Get(sforceLimitInfoHeader)) {
+					delay *= 2
+				}
+				_ = resp.Body.Close()
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(delay):
+				}
+
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, fmt.Errorf("restclient: failed to rewind request body for retry: %w", err)
+					}
+					req.Body = body
+				}
+			}
+			return resp, err
+		}
+	}
+}
+
+func isRetryableResponse(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoffDelay returns policy.BaseDelay doubled attempt times (capped at
+// policy.MaxDelay), with up to 50% jitter applied.
+func backoffDelay(policy BackoffPolicy, attempt int) time.Duration {
+	delay := time.Duration(float64(policy.BaseDelay) * math.Pow(2, float64(attempt)))
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) + 1))
+	return delay/2 + jitter/2
+}
+
+// nearAPILimit reports whether a Sforce-Limit-Info header value like
+// "api-usage=18000/20000" shows usage over 90% of the org's daily limit.
+func nearAPILimit(header string) bool {
+	const prefix = "api-usage="
+	idx := strings.Index(header, prefix)
+	if idx == -1 {
+		return false
+	}
+
+	usage := header[idx+len(prefix):]
+	if comma := strings.Index(usage, ","); comma != -1 {
+		usage = usage[:comma]
+	}
+	parts := strings.SplitN(usage, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	used, err1 := strconv.ParseFloat(parts[0], 64)
+	total, err2 := strconv.ParseFloat(parts[1], 64)
+	if err1 != nil || err2 != nil || total == 0 {
+		return false
+	}
+	return used/total > 0.9
+}
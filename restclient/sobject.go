@@ -0,0 +1,11 @@
+package restclient
+
+// SObject is a generic representation of a Salesforce object, keyed by field name.
+type SObject map[string]interface{}
+
+// UpsertResult is the response of a create/upsert SObject request.
+type UpsertResult struct {
+	ID      string        `json:"id"`
+	Success bool          `json:"success"`
+	Errors  []interface{} `json:"errors"`
+}
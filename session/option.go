@@ -0,0 +1,70 @@
+package session
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/Laugusti/go-sforce/internal/httputil"
+)
+
+// Option configures a Session constructed by New.
+type Option func(*Session)
+
+// WithHTTPClient sets the http.Client used to make the login request,
+// overriding the default of http.DefaultClient. Options are applied in
+// order: a WithTransport, WithTLSConfig, WithProxy or WithInsecureSkipVerify
+// given before it is discarded, and one given after it modifies c itself.
+func WithHTTPClient(c *http.Client) Option {
+	return func(s *Session) {
+		s.httpClient = c
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by the Session's http.Client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(s *Session) {
+		s.transportClient().Transport = rt
+	}
+}
+
+// WithTLSConfig sets the tls.Config used by the Session's http.Client,
+// for connecting to Salesforce Government Cloud or through a corporate
+// proxy that terminates TLS with a private CA.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Session) {
+		s.transport().TLSClientConfig = cfg
+	}
+}
+
+// WithProxy routes the Session's login request through the given proxy URL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(s *Session) {
+		s.transport().Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the
+// Session's http.Client. It's meant for sandbox testing against servers with
+// self-signed certificates, never for production use.
+func WithInsecureSkipVerify() Option {
+	return func(s *Session) {
+		t := s.transport()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.InsecureSkipVerify = true
+	}
+}
+
+// transportClient returns s.httpClient, allocating one if it's still the
+// package default.
+func (s *Session) transportClient() *http.Client {
+	return httputil.TransportClient(&s.httpClient)
+}
+
+// transport returns the *http.Transport backing s.httpClient, cloning
+// http.DefaultTransport into it if it isn't one already.
+func (s *Session) transport() *http.Transport {
+	return httputil.Transport(&s.httpClient)
+}
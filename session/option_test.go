@@ -0,0 +1,65 @@
+package session
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/credentials"
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransportOption(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+	s.HandlerFunc = testserver.StaticJSONHandler(t, RequestToken{AccessToken: "MOCK_TOKEN", InstanceURL: s.URL()},
+		http.StatusOK)
+
+	var calls int
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	sess, err := New(s.URL(), "mock", credentials.New("user", "pass", "cid", "csecret"), WithTransport(rt))
+	assert.Nil(t, err)
+	assert.Nil(t, sess.Login())
+	assert.Equal(t, 1, calls, "expected the login request to go through the custom transport")
+}
+
+func TestWithTLSConfigOption(t *testing.T) {
+	s := testserver.NewTLS(t)
+	defer s.Stop()
+	s.HandlerFunc = testserver.StaticJSONHandler(t, RequestToken{AccessToken: "MOCK_TOKEN", InstanceURL: s.URL()},
+		http.StatusOK)
+
+	// a custom tls.Config that skips verification proves WithTLSConfig
+	// actually wires the config into the http.Client, independent of the
+	// dedicated WithInsecureSkipVerify option.
+	sess, err := New(s.URL(), "mock", credentials.New("user", "pass", "cid", "csecret"),
+		WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+	assert.Nil(t, err)
+	assert.Nil(t, sess.Login(), "login over TLS should've succeeded with the custom tls.Config")
+}
+
+func TestWithProxyOption(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.example.com:8080")
+	assert.Nil(t, err)
+
+	sess := Must(New("https://login.salesforce.com", "mock", credentials.New("user", "pass", "cid", "csecret"),
+		WithProxy(proxyURL)))
+
+	req, err := http.NewRequest(http.MethodPost, sess.LoginURL+"/services/oauth2/token", nil)
+	assert.Nil(t, err)
+	got, err := sess.transport().Proxy(req)
+	assert.Nil(t, err)
+	assert.Equal(t, proxyURL, got, "expected the login request to be routed through the configured proxy")
+}
@@ -0,0 +1,117 @@
+// Package session handles authenticating with Salesforce and holds the
+// instance URL and access token that the restclient package needs to make
+// authenticated requests.
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Laugusti/go-sforce/credentials"
+	"github.com/Laugusti/go-sforce/internal/requestid"
+)
+
+// RequestToken is the response returned by the Salesforce OAuth2 token endpoint.
+type RequestToken struct {
+	AccessToken string `json:"access_token"`
+	InstanceURL string `json:"instance_url"`
+}
+
+// Session holds the state needed to make authenticated requests to the
+// Salesforce REST API. Use New to create one, then Login (or LoginContext)
+// to authenticate it.
+type Session struct {
+	LoginURL    string
+	APIVersion  string
+	InstanceURL string
+	AccessToken string
+
+	creds      *credentials.Credentials
+	httpClient *http.Client
+}
+
+// New returns a new Session for the given login URL, API version and
+// credentials. By default, logins are made with http.DefaultClient; use
+// WithHTTPClient, WithTransport, WithTLSConfig or WithInsecureSkipVerify to
+// override that.
+func New(loginURL, apiVersion string, creds *credentials.Credentials, opts ...Option) (*Session, error) {
+	if loginURL == "" {
+		return nil, errors.New("session: login url is required")
+	}
+	if apiVersion == "" {
+		return nil, errors.New("session: api version is required")
+	}
+	if creds == nil {
+		return nil, errors.New("session: credentials are required")
+	}
+	s := &Session{
+		LoginURL:   loginURL,
+		APIVersion: apiVersion,
+		creds:      creds,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Must returns s, panicking if err is non-nil. It's a convenience wrapper
+// for session.New when used in variable initialization.
+func Must(s *Session, err error) *Session {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Login authenticates with Salesforce using the OAuth2 username-password flow.
+func (s *Session) Login() error {
+	return s.LoginContext(context.Background())
+}
+
+// LoginContext is the context-aware variant of Login. The request ID carried
+// by ctx (or a newly generated one, if ctx doesn't carry one) is sent as the
+// X-Request-ID header on the login call, so it can be correlated with the
+// client call that triggered it.
+func (s *Session) LoginContext(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("client_id", s.creds.ClientID)
+	form.Set("client_secret", s.creds.ClientSecret)
+	form.Set("username", s.creds.Username)
+	form.Set("password", s.creds.Password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.LoginURL+"/services/oauth2/token",
+		bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("session: failed to create login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(requestid.Header, requestid.FromContextOrNew(ctx))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("session: login request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("session: login failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var token RequestToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("session: failed to decode login response: %w", err)
+	}
+	s.AccessToken = token.AccessToken
+	s.InstanceURL = token.InstanceURL
+	return nil
+}
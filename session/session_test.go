@@ -0,0 +1,84 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Laugusti/go-sforce/credentials"
+	"github.com/Laugusti/go-sforce/internal/testserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	validCreds := credentials.New("user", "pass", "cid", "csecret")
+	tests := []struct {
+		loginURL   string
+		apiVersion string
+		creds      *credentials.Credentials
+		errSnippet string
+	}{
+		{"", "mock", validCreds, "login url is required"},
+		{"https://login.salesforce.com", "", validCreds, "api version is required"},
+		{"https://login.salesforce.com", "mock", nil, "credentials are required"},
+		{"https://login.salesforce.com", "mock", validCreds, ""},
+	}
+
+	for _, test := range tests {
+		assertMsg := fmt.Sprintf("input: %v", test)
+		sess, err := New(test.loginURL, test.apiVersion, test.creds)
+		if test.errSnippet == "" {
+			assert.Nil(t, err, assertMsg)
+			if assert.NotNil(t, sess, assertMsg) {
+				assert.Equal(t, test.loginURL, sess.LoginURL, assertMsg)
+				assert.Equal(t, test.apiVersion, sess.APIVersion, assertMsg)
+			}
+		} else {
+			assert.Nil(t, sess, assertMsg)
+			if assert.NotNil(t, err, assertMsg) {
+				assert.Contains(t, err.Error(), test.errSnippet, assertMsg)
+			}
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+
+	s.HandlerFunc = testserver.StaticJSONHandler(t, RequestToken{AccessToken: "tok", InstanceURL: s.URL()},
+		http.StatusOK)
+	sess := Must(New(s.URL(), "mock", credentials.New("user", "pass", "cid", "csecret")))
+	assert.Nil(t, sess.Login())
+	assert.Equal(t, "tok", sess.AccessToken)
+	assert.Equal(t, s.URL(), sess.InstanceURL)
+}
+
+func TestLogin_NonOKStatus(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+
+	s.HandlerFunc = testserver.StaticJSONHandler(t, map[string]string{"error": "invalid_grant"},
+		http.StatusBadRequest)
+	sess := Must(New(s.URL(), "mock", credentials.New("user", "pass", "cid", "csecret")))
+	err := sess.Login()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid_grant")
+	}
+	assert.Equal(t, "", sess.AccessToken, "access token shouldn't be set on a failed login")
+}
+
+func TestLogin_MalformedResponseBody(t *testing.T) {
+	s := testserver.New(t)
+	defer s.Stop()
+
+	s.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("not json"))
+	}
+	sess := Must(New(s.URL(), "mock", credentials.New("user", "pass", "cid", "csecret")))
+	err := sess.Login()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "failed to decode login response")
+	}
+}